@@ -0,0 +1,80 @@
+package gomocket
+
+import (
+	"context"
+	"database/sql/driver"
+	"testing"
+	"time"
+)
+
+func TestQueryContextRejectsAlreadyCancelledContext(t *testing.T) {
+	s := &FakeStmt{q: "SELECT 1", command: "SELECT"}
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	if _, err := s.QueryContext(ctx, nil); err != ctx.Err() {
+		t.Fatalf("QueryContext err = %v, want %v", err, ctx.Err())
+	}
+}
+
+func TestExecContextRejectsAlreadyCancelledContext(t *testing.T) {
+	s := &FakeStmt{q: "INSERT INTO t VALUES (1)", command: "INSERT"}
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	if _, err := s.ExecContext(ctx, nil); err != ctx.Err() {
+		t.Fatalf("ExecContext err = %v, want %v", err, ctx.Err())
+	}
+}
+
+func TestRowsCursorNextRejectsCancelledContext(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	rc := &RowsCursor{
+		posRow: -1,
+		rows:   [][]*row{{{cols: []interface{}{"a"}}}},
+		cols:   []string{"x"},
+		errPos: -1,
+		ctx:    ctx,
+	}
+	if err := rc.Next(make([]driver.Value, 1)); err != ctx.Err() {
+		t.Fatalf("Next err = %v, want %v", err, ctx.Err())
+	}
+}
+
+// TestCallbackGoroutineJoinableAfterCancellation exercises the case where a
+// Response.Callback is still running when ctx is cancelled: ExecContext
+// returns immediately with ctx.Err(), but the callback goroutine keeps
+// running in the background. It verifies a caller can join on
+// FakeStmt.callbackDone instead of racing the callback's side effects
+// against its own teardown.
+func TestCallbackGoroutineJoinableAfterCancellation(t *testing.T) {
+	savepointScopes = nil
+	var ranAt time.Time
+	ScopeToSavepoint("join-test", "SELECT 1", FakeResponse{
+		Callback: func(string, []driver.NamedValue) {
+			time.Sleep(30 * time.Millisecond)
+			ranAt = time.Now()
+		},
+	})
+
+	tx := &FakeTx{}
+	tx.savepoints = append(tx.savepoints, "join-test")
+	s := &FakeStmt{connection: &FakeConn{currTx: tx}, q: "SELECT 1", command: "SELECT"}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Millisecond)
+	defer cancel()
+
+	if _, err := s.ExecContext(ctx, nil); err != context.DeadlineExceeded {
+		t.Fatalf("ExecContext err = %v, want context.DeadlineExceeded", err)
+	}
+
+	// The callback is very likely still in flight here; join on
+	// callbackDone rather than leaking the goroutine past the end of the
+	// test or racing ranAt against the goroutine that sets it.
+	<-s.callbackDone
+	if ranAt.IsZero() {
+		t.Fatalf("callbackDone closed before the callback ran")
+	}
+}
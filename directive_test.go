@@ -0,0 +1,52 @@
+package gomocket
+
+import (
+	"context"
+	"database/sql/driver"
+	"testing"
+	"time"
+)
+
+func TestExecContextPanicsOnPanicDirective(t *testing.T) {
+	savepointScopes = nil
+	ScopeToSavepoint("panic-test", "SELECT 1", FakeResponse{
+		Panics: map[string]bool{MethodExecContext: true},
+	})
+
+	tx := &FakeTx{}
+	tx.savepoints = append(tx.savepoints, "panic-test")
+	s := &FakeStmt{connection: &FakeConn{currTx: tx}, q: "SELECT 1", command: "SELECT"}
+
+	defer func() {
+		r := recover()
+		if r == nil {
+			t.Fatalf("ExecContext did not panic despite a PANIC directive on %s", MethodExecContext)
+		}
+	}()
+	s.ExecContext(context.Background(), nil)
+}
+
+func TestExecContextWaitCutShortByContextCancellation(t *testing.T) {
+	savepointScopes = nil
+	ScopeToSavepoint("wait-test", "SELECT 2", FakeResponse{
+		Delays: map[string]time.Duration{MethodExecContext: time.Second},
+	})
+
+	tx := &FakeTx{}
+	tx.savepoints = append(tx.savepoints, "wait-test")
+	s := &FakeStmt{connection: &FakeConn{currTx: tx}, q: "SELECT 2", command: "SELECT"}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	start := time.Now()
+	_, err := s.ExecContext(ctx, []driver.NamedValue(nil))
+	elapsed := time.Since(start)
+
+	if err != context.DeadlineExceeded {
+		t.Fatalf("ExecContext err = %v, want context.DeadlineExceeded", err)
+	}
+	if elapsed >= time.Second {
+		t.Fatalf("ExecContext blocked for the full WAIT delay (%v) instead of returning once ctx expired", elapsed)
+	}
+}
@@ -0,0 +1,57 @@
+package gomocket
+
+import (
+	"context"
+	"database/sql/driver"
+	"testing"
+)
+
+type recordingHooks struct {
+	beforeQuery []string
+	afterQuery  []*HookContext
+}
+
+func (h *recordingHooks) BeforeExec(ctx *HookContext)     {}
+func (h *recordingHooks) AfterExec(ctx *HookContext)      {}
+func (h *recordingHooks) BeforeCommit(ctx *HookContext)   {}
+func (h *recordingHooks) AfterCommit(ctx *HookContext)    {}
+func (h *recordingHooks) BeforeRollback(ctx *HookContext) {}
+func (h *recordingHooks) AfterRollback(ctx *HookContext)  {}
+
+func (h *recordingHooks) BeforeQuery(ctx *HookContext) {
+	h.beforeQuery = append(h.beforeQuery, ctx.Query)
+}
+
+func (h *recordingHooks) AfterQuery(ctx *HookContext) {
+	h.afterQuery = append(h.afterQuery, ctx)
+}
+
+func TestActiveHooksSeeResolvedQueryAndArgs(t *testing.T) {
+	hooks := &recordingHooks{}
+	ActiveHooks = hooks
+	defer func() { ActiveHooks = nil }()
+
+	s := &FakeStmt{q: "SELECT * FROM t WHERE id = ?", command: "SELECT"}
+	args := []driver.NamedValue{{Ordinal: 1, Value: int64(7)}}
+
+	if _, err := s.QueryContext(context.Background(), args); err != nil {
+		t.Fatalf("QueryContext: %v", err)
+	}
+
+	if len(hooks.beforeQuery) != 1 || hooks.beforeQuery[0] != "SELECT * FROM t WHERE id = ?" {
+		t.Fatalf("BeforeQuery saw %v, want the unresolved template since it runs before queryContext", hooks.beforeQuery)
+	}
+	if len(hooks.afterQuery) != 1 {
+		t.Fatalf("AfterQuery called %d times, want 1", len(hooks.afterQuery))
+	}
+	got := hooks.afterQuery[0]
+	if got.Query != "SELECT * FROM t WHERE id = 7" {
+		t.Errorf("AfterQuery saw Query = %q, want the resolved SQL %q", got.Query, "SELECT * FROM t WHERE id = 7")
+	}
+	if len(got.Args) != 1 || got.Args[0].Value != int64(7) {
+		t.Errorf("AfterQuery saw Args = %v, want the bound args passed to QueryContext", got.Args)
+	}
+	if got.Err != nil {
+		t.Errorf("AfterQuery saw Err = %v, want nil", got.Err)
+	}
+}
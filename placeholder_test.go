@@ -0,0 +1,28 @@
+package gomocket
+
+import (
+	"context"
+	"database/sql/driver"
+	"testing"
+)
+
+func TestQueryContextReResolvesPlaceholdersOnEachCall(t *testing.T) {
+	s := &FakeStmt{q: "SELECT * FROM t WHERE id = ?", command: "SELECT"}
+
+	if _, err := s.QueryContext(context.Background(), []driver.NamedValue{{Ordinal: 1, Value: int64(1)}}); err != nil {
+		t.Fatalf("first QueryContext: %v", err)
+	}
+	q1 := s.q
+
+	if _, err := s.QueryContext(context.Background(), []driver.NamedValue{{Ordinal: 1, Value: int64(2)}}); err != nil {
+		t.Fatalf("second QueryContext: %v", err)
+	}
+	q2 := s.q
+
+	if q1 == q2 {
+		t.Fatalf("second call did not re-resolve placeholders: query still %q (arg2=2 never substituted)", q2)
+	}
+	if q2 != "SELECT * FROM t WHERE id = 2" {
+		t.Errorf("q2 = %q, want %q", q2, "SELECT * FROM t WHERE id = 2")
+	}
+}
@@ -0,0 +1,45 @@
+package gomocket
+
+import (
+	"context"
+	"testing"
+)
+
+// TestExecContextNestedSamedNamedSavepointsReleaseInnermostOnly drives
+// SAVEPOINT/RELEASE SAVEPOINT through FakeStmt.ExecContext itself (rather
+// than calling findScopedResponse/popSavepointsFrom directly), covering two
+// nested savepoints sharing the same name the way some drivers generate
+// them (e.g. repeated use of the same named sub-transaction helper).
+func TestExecContextNestedSamedNamedSavepointsReleaseInnermostOnly(t *testing.T) {
+	conn := &FakeConn{}
+	tx := &FakeTx{c: conn}
+	conn.currTx = tx
+
+	exec := func(q, command string) {
+		s := &FakeStmt{connection: conn, q: q, command: command}
+		if _, err := s.ExecContext(context.Background(), nil); err != nil {
+			t.Fatalf("ExecContext(%q): %v", q, err)
+		}
+	}
+
+	exec("SAVEPOINT s1", "SAVEPOINT")
+	exec("SAVEPOINT s1", "SAVEPOINT")
+	if got := len(tx.savepoints); got != 2 {
+		t.Fatalf("after two nested SAVEPOINT s1, len(savepoints) = %d, want 2", got)
+	}
+
+	exec("RELEASE SAVEPOINT s1", "RELEASE")
+	if got := len(tx.savepoints); got != 1 {
+		t.Fatalf("RELEASE SAVEPOINT s1 released %d savepoints, want exactly the innermost (1 left)", 2-got)
+	}
+
+	exec("ROLLBACK TO SAVEPOINT s1", "ROLLBACK")
+	if got := len(tx.savepoints); got != 1 || tx.savepoints[0] != "s1" {
+		t.Fatalf("savepoints after ROLLBACK TO SAVEPOINT s1 = %v, want the outer s1 still active", tx.savepoints)
+	}
+
+	exec("RELEASE SAVEPOINT s1", "RELEASE")
+	if got := len(tx.savepoints); got != 0 {
+		t.Fatalf("savepoints after releasing the outer s1 = %d, want 0", got)
+	}
+}
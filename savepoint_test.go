@@ -0,0 +1,24 @@
+package gomocket
+
+import "testing"
+
+func TestScopeToSavepointOnlyMatchesInsideSavepoint(t *testing.T) {
+	savepointScopes = nil
+	ScopeToSavepoint("s1", "SELECT 1", FakeResponse{LastInsertID: 42})
+
+	tx := &FakeTx{}
+	if _, ok := findScopedResponse(tx, "SELECT 1"); ok {
+		t.Fatalf("scoped response matched before SAVEPOINT s1 was active")
+	}
+
+	tx.savepoints = append(tx.savepoints, "s1")
+	resp, ok := findScopedResponse(tx, "SELECT 1")
+	if !ok || resp.LastInsertID != 42 {
+		t.Fatalf("findScopedResponse = (%v, %v), want the registered response while s1 is active", resp, ok)
+	}
+
+	tx.popSavepointsFrom("s1")
+	if _, ok := findScopedResponse(tx, "SELECT 1"); ok {
+		t.Fatalf("scoped response still matched after RELEASE SAVEPOINT s1")
+	}
+}
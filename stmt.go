@@ -5,20 +5,244 @@ import (
 	"database/sql/driver"
 	"errors"
 	"fmt"
+	"io"
 	"math/rand"
+	"reflect"
+	"sort"
 	"strings"
+	"time"
 )
 
+// buildMapRowSet converts one result set of map[string]interface{} records
+// into cursor rows, sorting column names for a deterministic Columns()
+// result since map iteration order is undefined.
+func buildMapRowSet(records []map[string]interface{}) ([]*row, []string, []Column) {
+	var columnNames []string
+	colIndexes := make(map[string]int)
+	if len(records) > 0 {
+		for colName := range records[0] {
+			columnNames = append(columnNames, colName)
+		}
+		sort.Strings(columnNames)
+		for i, colName := range columnNames {
+			colIndexes[colName] = i
+		}
+	}
+
+	colMeta := make([]Column, len(columnNames))
+	for i, name := range columnNames {
+		colMeta[i] = Column{Name: name}
+	}
+
+	rows := make([]*row, 0, len(records))
+	for _, record := range records {
+		oneRow := &row{cols: make([]interface{}, len(columnNames))}
+		for _, col := range columnNames {
+			oneRow.cols[colIndexes[col]] = record[col]
+		}
+		rows = append(rows, oneRow)
+	}
+	return rows, columnNames, colMeta
+}
+
+// HasNextResultSet reports whether another result set is available,
+// supporting responses registered with ResultSets.
+func (rc *RowsCursor) HasNextResultSet() bool {
+	return rc.resultSetIdx+1 < len(rc.rows)
+}
+
+// NextResultSet advances the cursor to the next registered result set,
+// resetting the row position and swapping in that set's columns.
+func (rc *RowsCursor) NextResultSet() error {
+	if !rc.HasNextResultSet() {
+		return io.EOF
+	}
+	rc.resultSetIdx++
+	rc.posRow = -1
+	rc.cols = rc.colsPerSet[rc.resultSetIdx]
+	rc.colMeta = rc.metaPerSet[rc.resultSetIdx]
+	return nil
+}
+
+// Column describes one column of an OrderedResponse, carrying the typed
+// metadata database/sql/driver's RowsColumnType* interfaces expose.
+type Column struct {
+	Name             string
+	DatabaseTypeName string
+	ScanType         reflect.Type
+	Nullable         bool
+}
+
+// OrderedResponse is a response fixture that, unlike the plain
+// map[string]interface{} rows accepted elsewhere, preserves column order
+// and carries typed column metadata. Register one when a test needs
+// rows.Columns() or rows.ColumnTypes() to behave deterministically.
+type OrderedResponse struct {
+	Columns []Column
+	Rows    [][]driver.Value
+}
+
+// toInterfaceSlice widens a row of driver.Value into the []interface{}
+// that row.cols stores.
+func toInterfaceSlice(values []driver.Value) []interface{} {
+	out := make([]interface{}, len(values))
+	for i, v := range values {
+		out[i] = v
+	}
+	return out
+}
+
+// RowsColumnTypeDatabaseTypeName returns the declared database type name
+// for the column at index, when the response was registered as an
+// OrderedResponse with typed Columns.
+func (rc *RowsCursor) RowsColumnTypeDatabaseTypeName(index int) string {
+	if index < 0 || index >= len(rc.colMeta) {
+		return ""
+	}
+	return rc.colMeta[index].DatabaseTypeName
+}
+
+// RowsColumnTypeScanType returns the Go type a column's values scan into,
+// when the response was registered as an OrderedResponse with typed
+// Columns.
+func (rc *RowsCursor) RowsColumnTypeScanType(index int) reflect.Type {
+	if index < 0 || index >= len(rc.colMeta) || rc.colMeta[index].ScanType == nil {
+		return reflect.TypeOf(new(interface{})).Elem()
+	}
+	return rc.colMeta[index].ScanType
+}
+
+// RowsColumnTypeNullable reports whether a column may contain NULL, when
+// the response was registered as an OrderedResponse with typed Columns.
+// Plain map[string]interface{} fixtures carry no nullability information,
+// so ok is false for those rather than defaulting to "definitely not null".
+func (rc *RowsCursor) RowsColumnTypeNullable(index int) (nullable, ok bool) {
+	if !rc.typedMeta || index < 0 || index >= len(rc.colMeta) {
+		return false, false
+	}
+	return rc.colMeta[index].Nullable, true
+}
+
+// RowsColumnTypeLength is unimplemented: this driver does not model
+// variable-length column sizes, so it always reports ok=false.
+func (rc *RowsCursor) RowsColumnTypeLength(index int) (length int64, ok bool) {
+	return 0, false
+}
+
+// Columns returns the names of the columns in the current result set.
+func (rc *RowsCursor) Columns() []string {
+	return rc.cols
+}
+
+// Close marks the cursor exhausted. Rows already delivered to the caller
+// remain valid; further calls to Next return io.EOF.
+func (rc *RowsCursor) Close() error {
+	rc.closed = true
+	return nil
+}
+
+// Next scans the next row of the current result set into dest, advancing
+// rc.posRow. It walks rc.rows[rc.resultSetIdx] rather than a fixed result
+// set so calls made after NextResultSet iterate the set that was switched
+// to, and it consults ctx between rows (not just at QueryContext time) so
+// a caller streaming many rows can still observe cancellation partway
+// through.
+func (rc *RowsCursor) Next(dest []driver.Value) error {
+	if rc.closed {
+		return io.EOF
+	}
+
+	maybePanic(rc.fResp, MethodNext)
+	if rc.ctx != nil {
+		if err := maybeWait(rc.ctx, rc.fResp, MethodNext); err != nil {
+			return err
+		}
+		if err := rc.ctx.Err(); err != nil {
+			return err
+		}
+	}
+
+	currentRows := rc.rows[rc.resultSetIdx]
+	rc.posRow++
+	if rc.posRow >= len(currentRows) {
+		return io.EOF
+	}
+	if rc.errPos >= 0 && rc.posRow == rc.errPos {
+		return fmt.Errorf("gomocket: simulated row scan error at row %d", rc.posRow)
+	}
+	for i, v := range currentRows[rc.posRow].cols {
+		dest[i] = v
+	}
+	return nil
+}
+
+// Directive method names usable with a Response's Panics/Delays maps, for
+// simulating a misbehaving driver the way database/sql/driver's internal
+// fakedb tests do.
+const (
+	MethodExecContext  = "ExecContext"
+	MethodQueryContext = "QueryContext"
+	MethodClose        = "Close"
+	MethodCommit       = "Commit"
+	MethodRollback     = "Rollback"
+	MethodNext         = "Next"
+)
+
+// maybePanic panics if fResp was registered with a PANIC directive for
+// method, for exercising code that must recover from a driver panic.
+func maybePanic(fResp FakeResponse, method string) {
+	if fResp.Panics != nil && fResp.Panics[method] {
+		panic(fmt.Sprintf("gomocket: simulated panic in %s", method))
+	}
+}
+
+// maybeWait blocks for fResp's configured WAIT latency for method, if any,
+// returning ctx.Err() instead if ctx is cancelled first so tests can
+// exercise context deadlines against slow queries.
+func maybeWait(ctx context.Context, fResp FakeResponse, method string) error {
+	if fResp.Delays == nil {
+		return nil
+	}
+	d, ok := fResp.Delays[method]
+	if !ok || d <= 0 {
+		return nil
+	}
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// maybeWaitNoCtx blocks for fResp's configured WAIT latency for method, if
+// any. It backs directives for driver methods that, per database/sql/driver,
+// take no context (Close, Commit, Rollback), so unlike maybeWait the wait
+// cannot be interrupted early.
+func maybeWaitNoCtx(fResp FakeResponse, method string) {
+	if fResp.Delays == nil {
+		return
+	}
+	if d, ok := fResp.Delays[method]; ok && d > 0 {
+		time.Sleep(d)
+	}
+}
+
 // FakeStmt  is implementation of Stmt sql interfcae
 type FakeStmt struct {
 	connection   *FakeConn
-	q            string    // just for debugging SQL query generated by sql package
-	command      string    // String name of the command SELECT etc, taken as first word in the query
-	next         *FakeStmt // used for returning multiple results.
-	closed       bool      // If connection closed already
-	colName      []string  // Names of columns in response
-	colType      []string  // Not used for now
-	placeholders int       // Amount of passed args
+	q            string        // just for debugging SQL query generated by sql package
+	template     string        // q before any placeholder was resolved, so a reused *sql.Stmt re-resolves from scratch on every call instead of compounding onto the previous call's values
+	command      string        // String name of the command SELECT etc, taken as first word in the query
+	next         *FakeStmt     // used for returning multiple results.
+	closed       bool          // If connection closed already
+	colName      []string      // Names of columns in response
+	colType      []string      // Not used for now
+	placeholders int           // Amount of passed args
+	lastResp     FakeResponse  // response matched by the most recent Exec/QueryContext, consulted by Close for its PANIC/WAIT directives
+	callbackDone chan struct{} // closed when the most recent Response.Callback goroutine finishes; join on it after a ctx cancellation left it running in the background
 }
 
 // ColumnConverter returns a ValueConverter for the provided
@@ -29,6 +253,9 @@ func (s *FakeStmt) ColumnConverter(idx int) driver.ValueConverter {
 
 // Close closes the connection
 func (s *FakeStmt) Close() error {
+	maybePanic(s.lastResp, MethodClose)
+	maybeWaitNoCtx(s.lastResp, MethodClose)
+
 	// No connection added
 	if s.connection == nil {
 		panic("nil conn in FakeStmt.Close")
@@ -47,6 +274,163 @@ func (s *FakeStmt) Close() error {
 
 var errClosed = errors.New("fake_db_driver: statement has been closed")
 
+// placeholderKind distinguishes the placeholder styles resolveQueryArgs
+// understands when substituting bound arguments into the debug SQL string.
+type placeholderKind int
+
+const (
+	placeholderPositional placeholderKind = iota // "?"
+	placeholderNamed                             // "@name" or ":name"
+)
+
+// placeholderToken is a single placeholder found by scanPlaceholders, with
+// the byte range it occupies in the original query.
+type placeholderToken struct {
+	kind       placeholderKind
+	name       string // empty for placeholderPositional
+	start, end int
+}
+
+// scanPlaceholders walks q looking for "?", "@name" and ":name"
+// placeholders, skipping over anything inside single-quoted string
+// literals and "--"/"/* */" comments so that punctuation appearing there
+// is never mistaken for a placeholder.
+func scanPlaceholders(q string) []placeholderToken {
+	var tokens []placeholderToken
+	inString := false
+	for i := 0; i < len(q); i++ {
+		c := q[i]
+		switch {
+		case inString:
+			if c == '\'' {
+				inString = false
+			}
+		case c == '\'':
+			inString = true
+		case c == '-' && i+1 < len(q) && q[i+1] == '-':
+			if j := strings.IndexByte(q[i:], '\n'); j >= 0 {
+				i += j
+			} else {
+				i = len(q)
+			}
+		case c == '/' && i+1 < len(q) && q[i+1] == '*':
+			if j := strings.Index(q[i+2:], "*/"); j >= 0 {
+				i += j + 3
+			} else {
+				i = len(q)
+			}
+		case c == '?':
+			tokens = append(tokens, placeholderToken{kind: placeholderPositional, start: i, end: i + 1})
+		case c == '@' || c == ':':
+			j := i + 1
+			for j < len(q) && isPlaceholderNameByte(q[j]) {
+				j++
+			}
+			if j > i+1 {
+				tokens = append(tokens, placeholderToken{kind: placeholderNamed, name: q[i+1 : j], start: i, end: j})
+				i = j - 1
+			}
+		}
+	}
+	return tokens
+}
+
+func isPlaceholderNameByte(b byte) bool {
+	return b == '_' || (b >= 'a' && b <= 'z') || (b >= 'A' && b <= 'Z') || (b >= '0' && b <= '9')
+}
+
+// resolveQueryArgs rewrites q for debugging/matching purposes, substituting
+// each placeholder with its bound value. Positional "?" placeholders
+// consume args in order; "@name"/":name" placeholders are resolved against
+// any driver.NamedValue whose Name matches, mirroring sql.Named.
+func resolveQueryArgs(q string, args []driver.NamedValue) string {
+	tokens := scanPlaceholders(q)
+	if len(tokens) == 0 {
+		return q
+	}
+
+	byName := make(map[string]driver.NamedValue)
+	var positional []driver.NamedValue
+	for _, a := range args {
+		if a.Name != "" {
+			byName[a.Name] = a
+		} else {
+			positional = append(positional, a)
+		}
+	}
+
+	var b strings.Builder
+	last, posIdx := 0, 0
+	for _, t := range tokens {
+		b.WriteString(q[last:t.start])
+		switch t.kind {
+		case placeholderPositional:
+			if posIdx < len(positional) {
+				b.WriteString(fmt.Sprintf("%v", positional[posIdx].Value))
+				posIdx++
+			} else {
+				b.WriteString(q[t.start:t.end])
+			}
+		case placeholderNamed:
+			if a, ok := byName[t.name]; ok {
+				b.WriteString(fmt.Sprintf("%v", a.Value))
+			} else {
+				b.WriteString(q[t.start:t.end])
+			}
+		}
+		last = t.end
+	}
+	b.WriteString(q[last:])
+	return b.String()
+}
+
+// Hooks lets callers observe every statement and transaction operation
+// performed through the mocked driver. Implementations are invoked
+// synchronously around each call so tests can assert on timing, log
+// invocations, or inject deterministic ordering assertions without
+// stubbing every response individually.
+type Hooks interface {
+	BeforeExec(ctx *HookContext)
+	AfterExec(ctx *HookContext)
+	BeforeQuery(ctx *HookContext)
+	AfterQuery(ctx *HookContext)
+	BeforeCommit(ctx *HookContext)
+	AfterCommit(ctx *HookContext)
+	BeforeRollback(ctx *HookContext)
+	AfterRollback(ctx *HookContext)
+}
+
+// ActiveHooks, when set, is invoked around every ExecContext, QueryContext,
+// Commit and Rollback call. It is nil by default so hooking in costs
+// nothing unless a test opts in.
+var ActiveHooks Hooks
+
+// HookContext carries the SQL text, bound arguments and resulting error
+// for a single operation. It also carries a small bag of user values that
+// a BeforeX hook can stash with Set and the matching AfterX hook can read
+// back with Get, e.g. to measure elapsed time.
+type HookContext struct {
+	Query string
+	Args  []driver.NamedValue
+	Err   error
+
+	values map[string]interface{}
+}
+
+// Set stores a value on the hook context for later retrieval by Get.
+func (c *HookContext) Set(key string, val interface{}) {
+	if c.values == nil {
+		c.values = make(map[string]interface{})
+	}
+	c.values[key] = val
+}
+
+// Get returns a value previously stored with Set, and whether it was found.
+func (c *HookContext) Get(key string) (interface{}, bool) {
+	val, ok := c.values[key]
+	return val, ok
+}
+
 // Exec executes a query that doesn't return rows, such
 // as an INSERT or UPDATE.
 //
@@ -58,11 +442,52 @@ func (s *FakeStmt) Exec(args []driver.Value) (driver.Result, error) {
 // ExecContext executes a query that doesn't return rows, such
 // as an INSERT or UPDATE.
 func (s *FakeStmt) ExecContext(ctx context.Context, args []driver.NamedValue) (driver.Result, error) {
+	hookCtx := &HookContext{Query: s.q, Args: args}
+	if ActiveHooks != nil {
+		ActiveHooks.BeforeExec(hookCtx)
+	}
+	res, err := s.execContext(ctx, args)
+	if ActiveHooks != nil {
+		hookCtx.Query = s.q // execContext resolves placeholders into s.q; reflect the SQL that actually ran
+		hookCtx.Err = err
+		ActiveHooks.AfterExec(hookCtx)
+	}
+	return res, err
+}
+
+// execContext holds the actual Exec implementation, wrapped by
+// ExecContext so BeforeExec/AfterExec see the final outcome.
+func (s *FakeStmt) execContext(ctx context.Context, args []driver.NamedValue) (driver.Result, error) {
 	if s.closed {
 		return nil, errClosed
 	}
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	if s.template == "" {
+		s.template = s.q
+	}
+	q := s.template
+	if len(args) > 0 {
+		q = resolveQueryArgs(q, args)
+	}
+	s.q = q
+
+	var currTx *FakeTx
+	if s.connection != nil {
+		currTx = s.connection.currTx
+	}
+	fResp, ok := findScopedResponse(currTx, q)
+	if !ok {
+		fResp = Catcher.FindResponse(q, args)
+	}
+	s.lastResp = fResp
 
-	fResp := Catcher.FindResponse(s.q, args)
+	maybePanic(fResp, MethodExecContext)
+	if err := maybeWait(ctx, fResp, MethodExecContext); err != nil {
+		return nil, err
+	}
 
 	// To emulate any exception during query which returns rows
 	if fResp.Exceptions != nil && fResp.Exceptions.HookExecBadConnection != nil && fResp.Exceptions.HookExecBadConnection() {
@@ -74,7 +499,31 @@ func (s *FakeStmt) ExecContext(ctx context.Context, args []driver.NamedValue) (d
 	}
 
 	if fResp.Callback != nil {
-		fResp.Callback(s.q, args)
+		done := make(chan struct{})
+		s.callbackDone = done
+		go func() {
+			fResp.Callback(q, args)
+			close(done)
+		}()
+		select {
+		case <-done:
+		case <-ctx.Done():
+			// The goroutine above keeps running after we return; s.callbackDone
+			// lets a caller that cares (e.g. a test whose Callback touches
+			// *testing.T) wait for it instead of racing its own teardown.
+			return nil, ctx.Err()
+		}
+	}
+
+	switch s.command {
+	case "SAVEPOINT":
+		return s.execSavepointCreate()
+	case "RELEASE":
+		return s.execSavepointRelease()
+	case "ROLLBACK":
+		if kind, _ := classifySavepoint(s.q); kind == savepointRollbackTo {
+			return s.execSavepointRollbackTo()
+		}
 	}
 
 	switch s.command {
@@ -106,20 +555,52 @@ func (s *FakeStmt) Query(args []driver.Value) (driver.Rows, error) {
 // QueryContext executes a query that may return rows, such as a
 // SELECT.
 func (s *FakeStmt) QueryContext(ctx context.Context, args []driver.NamedValue) (driver.Rows, error) {
+	hookCtx := &HookContext{Query: s.q, Args: args}
+	if ActiveHooks != nil {
+		ActiveHooks.BeforeQuery(hookCtx)
+	}
+	rows, err := s.queryContext(ctx, args)
+	if ActiveHooks != nil {
+		hookCtx.Query = s.q // queryContext resolves placeholders into s.q; reflect the SQL that actually ran
+		hookCtx.Err = err
+		ActiveHooks.AfterQuery(hookCtx)
+	}
+	return rows, err
+}
 
+// queryContext holds the actual Query implementation, wrapped by
+// QueryContext so BeforeQuery/AfterQuery see the final outcome.
+func (s *FakeStmt) queryContext(ctx context.Context, args []driver.NamedValue) (driver.Rows, error) {
 	if s.closed {
 		return nil, errClosed
 	}
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
 
+	if s.template == "" {
+		s.template = s.q
+	}
+	q := s.template
 	if len(args) > 0 {
-		// Replace all "?" to "%v" and replace them with the values after
-		for i := 0; i < len(args); i++ {
-			s.q = strings.Replace(s.q, "?", "%v", 1)
-			s.q = fmt.Sprintf(s.q, args[i].Value)
-		}
+		q = resolveQueryArgs(q, args)
+	}
+	s.q = q
+
+	var currTx *FakeTx
+	if s.connection != nil {
+		currTx = s.connection.currTx
+	}
+	fResp, ok := findScopedResponse(currTx, q)
+	if !ok {
+		fResp = Catcher.FindResponse(q, args)
 	}
+	s.lastResp = fResp
 
-	fResp := Catcher.FindResponse(s.q, args)
+	maybePanic(fResp, MethodQueryContext)
+	if err := maybeWait(ctx, fResp, MethodQueryContext); err != nil {
+		return nil, err
+	}
 
 	if fResp.Exceptions != nil && fResp.Exceptions.HookQueryBadConnection != nil && fResp.Exceptions.HookQueryBadConnection() {
 		return nil, driver.ErrBadConn
@@ -129,43 +610,76 @@ func (s *FakeStmt) QueryContext(ctx context.Context, args []driver.NamedValue) (
 		return nil, fResp.Error
 	}
 
-	resultRows := make([][]*row, 0, 1)
-	columnNames := make([]string, 0, 1)
 	columnTypes := make([][]string, 0, 1)
-	rows := []*row{}
 
-	// Check if we have such query in the map
-	colIndexes := make(map[string]int)
-
-	// Collecting column names from first record
-	if len(fResp.Response) > 0 {
-		for colName := range fResp.Response[0] {
-			colIndexes[colName] = len(columnNames)
-			columnNames = append(columnNames, colName)
+	var resultRows [][]*row
+	var colsPerSet [][]string
+	var metaPerSet [][]Column
+	var typedMeta bool
+
+	switch {
+	case len(fResp.ResultSets) > 0:
+		// One registered response delivering several result sets, walked
+		// in order via RowsCursor.NextResultSet.
+		for _, set := range fResp.ResultSets {
+			rows, columnNames, colMeta := buildMapRowSet(set)
+			resultRows = append(resultRows, rows)
+			colsPerSet = append(colsPerSet, columnNames)
+			metaPerSet = append(metaPerSet, colMeta)
 		}
-	}
-
-	// Extracting values from result according columns
-	for _, record := range fResp.Response {
-		oneRow := &row{cols: make([]interface{}, len(columnNames))}
-		for _, col := range columnNames {
-			oneRow.cols[colIndexes[col]] = record[col]
+	case fResp.Ordered != nil:
+		// Already in column order: no sorting needed, and typed column
+		// metadata is taken as given.
+		columnNames := make([]string, len(fResp.Ordered.Columns))
+		rows := make([]*row, 0, len(fResp.Ordered.Rows))
+		for i, c := range fResp.Ordered.Columns {
+			columnNames[i] = c.Name
 		}
-		rows = append(rows, oneRow)
+		for _, record := range fResp.Ordered.Rows {
+			rows = append(rows, &row{cols: toInterfaceSlice(record)})
+		}
+		resultRows = append(resultRows, rows)
+		colsPerSet = append(colsPerSet, columnNames)
+		metaPerSet = append(metaPerSet, fResp.Ordered.Columns)
+		typedMeta = true
+	default:
+		rows, columnNames, colMeta := buildMapRowSet(fResp.Response)
+		resultRows = append(resultRows, rows)
+		colsPerSet = append(colsPerSet, columnNames)
+		metaPerSet = append(metaPerSet, colMeta)
 	}
-	resultRows = append(resultRows, rows)
 
 	cursor := &RowsCursor{
-		posRow:  -1,
-		rows:    resultRows,
-		cols:    columnNames,
-		colType: columnTypes, // TODO: implement support of that
-		errPos:  -1,
-		closed:  false,
+		posRow:       -1,
+		rows:         resultRows,
+		cols:         colsPerSet[0],
+		colsPerSet:   colsPerSet,
+		resultSetIdx: 0,
+		colType:      columnTypes, // TODO: implement support of that
+		colMeta:      metaPerSet[0],
+		metaPerSet:   metaPerSet,
+		errPos:       -1,
+		closed:       false,
+		ctx:          ctx,   // exposed for the cursor's Next to check between rows, mirroring the deadline check above
+		fResp:        fResp, // consulted by Next for its PANIC/WAIT directives
+		typedMeta:    typedMeta,
 	}
 
 	if fResp.Callback != nil {
-		fResp.Callback(s.q, args)
+		done := make(chan struct{})
+		s.callbackDone = done
+		go func() {
+			fResp.Callback(q, args)
+			close(done)
+		}()
+		select {
+		case <-done:
+		case <-ctx.Done():
+			// The goroutine above keeps running after we return; s.callbackDone
+			// lets a caller that cares (e.g. a test whose Callback touches
+			// *testing.T) wait for it instead of racing its own teardown.
+			return nil, ctx.Err()
+		}
 	}
 
 	return cursor, nil
@@ -176,9 +690,152 @@ func (s *FakeStmt) NumInput() int {
 	return s.placeholders
 }
 
+// savepointDirective classifies a SAVEPOINT-related statement so
+// execContext can simulate it against the current transaction instead of
+// treating it as a generic command.
+type savepointDirective int
+
+const (
+	savepointNone savepointDirective = iota
+	savepointCreate
+	savepointRelease
+	savepointRollbackTo
+)
+
+// classifySavepoint inspects q for a SAVEPOINT, RELEASE SAVEPOINT or
+// ROLLBACK TO [SAVEPOINT] statement and returns its kind plus the
+// savepoint name.
+func classifySavepoint(q string) (savepointDirective, string) {
+	fields := strings.Fields(q)
+	eq := func(i int, kw string) bool { return i < len(fields) && strings.EqualFold(fields[i], kw) }
+	switch {
+	case eq(0, "SAVEPOINT") && len(fields) >= 2:
+		return savepointCreate, fields[1]
+	case eq(0, "RELEASE") && eq(1, "SAVEPOINT") && len(fields) >= 3:
+		return savepointRelease, fields[2]
+	case eq(0, "ROLLBACK") && eq(1, "TO") && eq(2, "SAVEPOINT") && len(fields) >= 4:
+		return savepointRollbackTo, fields[3]
+	case eq(0, "ROLLBACK") && eq(1, "TO") && len(fields) >= 3:
+		return savepointRollbackTo, fields[2]
+	}
+	return savepointNone, ""
+}
+
+// execSavepointCreate simulates "SAVEPOINT name", pushing it onto the
+// current transaction's savepoint stack.
+func (s *FakeStmt) execSavepointCreate() (driver.Result, error) {
+	if HookBadSavepoint != nil && HookBadSavepoint() {
+		return nil, driver.ErrBadConn
+	}
+	_, name := classifySavepoint(s.q)
+	if tx := s.connection.currTx; tx != nil {
+		tx.savepoints = append(tx.savepoints, name)
+	}
+	return driver.RowsAffected(0), nil
+}
+
+// execSavepointRelease simulates "RELEASE SAVEPOINT name", dropping it
+// (and any savepoints nested inside it) from the stack.
+func (s *FakeStmt) execSavepointRelease() (driver.Result, error) {
+	if HookBadReleaseSavepoint != nil && HookBadReleaseSavepoint() {
+		return nil, driver.ErrBadConn
+	}
+	_, name := classifySavepoint(s.q)
+	if tx := s.connection.currTx; tx != nil {
+		tx.popSavepointsFrom(name)
+	}
+	return driver.RowsAffected(0), nil
+}
+
+// execSavepointRollbackTo simulates "ROLLBACK TO SAVEPOINT name",
+// discarding any savepoints nested inside it while keeping it on the
+// stack, matching real nested-transaction semantics.
+func (s *FakeStmt) execSavepointRollbackTo() (driver.Result, error) {
+	_, name := classifySavepoint(s.q)
+	if tx := s.connection.currTx; tx != nil {
+		tx.popSavepointsFrom(name)
+		tx.savepoints = append(tx.savepoints, name)
+	}
+	return driver.RowsAffected(0), nil
+}
+
 // FakeTx implements Tx interface
 type FakeTx struct {
 	c *FakeConn
+
+	savepoints []string // names of active savepoints, innermost last
+}
+
+// HookBadSavepoint is a hook to simulate broken connections when issuing
+// a SAVEPOINT.
+var HookBadSavepoint func() bool
+
+// HookBadReleaseSavepoint is a hook to simulate broken connections when
+// releasing a SAVEPOINT.
+var HookBadReleaseSavepoint func() bool
+
+// popSavepointsFrom removes name and everything pushed after it from the
+// savepoint stack. It matches the innermost (most recently pushed)
+// occurrence of name, so shadowing a reused savepoint name in a nested
+// scope only releases/rolls back that inner one.
+func (tx *FakeTx) popSavepointsFrom(name string) {
+	for i := len(tx.savepoints) - 1; i >= 0; i-- {
+		if tx.savepoints[i] == name {
+			tx.savepoints = tx.savepoints[:i]
+			return
+		}
+	}
+}
+
+// HasSavepoint reports whether name is currently an active savepoint on
+// this transaction. Response matchers can consult a statement's
+// s.connection.currTx.HasSavepoint to scope a fixture to "only match
+// inside savepoint X".
+func (tx *FakeTx) HasSavepoint(name string) bool {
+	for _, sp := range tx.savepoints {
+		if sp == name {
+			return true
+		}
+	}
+	return false
+}
+
+// savepointScope is one response registered with ScopeToSavepoint: it
+// matches query only while savepoint is an active savepoint on the
+// connection's current transaction.
+type savepointScope struct {
+	savepoint string
+	query     string
+	resp      FakeResponse
+}
+
+// savepointScopes holds every response registered via ScopeToSavepoint, in
+// registration order so the most recently added match wins.
+var savepointScopes []savepointScope
+
+// ScopeToSavepoint registers resp to match ExecContext/QueryContext calls
+// for query only while savepoint is active on the statement's connection,
+// letting a test assert different behavior for the same query depending on
+// which nested transaction scope it runs inside.
+func ScopeToSavepoint(savepoint, query string, resp FakeResponse) {
+	savepointScopes = append(savepointScopes, savepointScope{savepoint: savepoint, query: query, resp: resp})
+}
+
+// findScopedResponse looks up a response registered with ScopeToSavepoint
+// for query, considering only savepoints currently active on tx. It checks
+// the most recently registered scope first so a later ScopeToSavepoint call
+// for the same (savepoint, query) pair overrides an earlier one.
+func findScopedResponse(tx *FakeTx, query string) (FakeResponse, bool) {
+	if tx == nil {
+		return FakeResponse{}, false
+	}
+	for i := len(savepointScopes) - 1; i >= 0; i-- {
+		sc := savepointScopes[i]
+		if sc.query == query && tx.HasSavepoint(sc.savepoint) {
+			return sc.resp, true
+		}
+	}
+	return FakeResponse{}, false
 }
 
 // HookBadCommit is a hook to simulate broken connections
@@ -186,7 +843,26 @@ var HookBadCommit func() bool
 
 // Commit commits the transaction
 func (tx *FakeTx) Commit() error {
+	hookCtx := &HookContext{}
+	if ActiveHooks != nil {
+		ActiveHooks.BeforeCommit(hookCtx)
+	}
+	err := tx.commit()
+	if ActiveHooks != nil {
+		hookCtx.Err = err
+		ActiveHooks.AfterCommit(hookCtx)
+	}
+	return err
+}
+
+func (tx *FakeTx) commit() error {
 	tx.c.currTx = nil
+	// Commit takes no query text, so it is matched against the sentinel
+	// "Commit" the same way a response registered with Panics/Delays for
+	// any other method would be.
+	fResp := Catcher.FindResponse(MethodCommit, nil)
+	maybePanic(fResp, MethodCommit)
+	maybeWaitNoCtx(fResp, MethodCommit)
 	if HookBadCommit != nil && HookBadCommit() {
 		return driver.ErrBadConn
 	}
@@ -198,7 +874,26 @@ var HookBadRollback func() bool
 
 // Rollback rollbacks the transaction
 func (tx *FakeTx) Rollback() error {
+	hookCtx := &HookContext{}
+	if ActiveHooks != nil {
+		ActiveHooks.BeforeRollback(hookCtx)
+	}
+	err := tx.rollback()
+	if ActiveHooks != nil {
+		hookCtx.Err = err
+		ActiveHooks.AfterRollback(hookCtx)
+	}
+	return err
+}
+
+func (tx *FakeTx) rollback() error {
 	tx.c.currTx = nil
+	// Rollback takes no query text, so it is matched against the sentinel
+	// "Rollback" the same way a response registered with Panics/Delays for
+	// any other method would be.
+	fResp := Catcher.FindResponse(MethodRollback, nil)
+	maybePanic(fResp, MethodRollback)
+	maybeWaitNoCtx(fResp, MethodRollback)
 	if HookBadRollback != nil && HookBadRollback() {
 		return driver.ErrBadConn
 	}
@@ -0,0 +1,47 @@
+package gomocket
+
+import (
+	"database/sql/driver"
+	"testing"
+)
+
+func TestNextIteratesSwitchedResultSet(t *testing.T) {
+	rc := &RowsCursor{
+		posRow: -1,
+		rows: [][]*row{
+			{{cols: []interface{}{"a"}}},
+			{{cols: []interface{}{"b"}}},
+		},
+		cols:         []string{"x"},
+		colsPerSet:   [][]string{{"x"}, {"x"}},
+		colMeta:      []Column{{Name: "x"}},
+		metaPerSet:   [][]Column{{{Name: "x"}}, {{Name: "x"}}},
+		resultSetIdx: 0,
+		errPos:       -1,
+	}
+	if err := rc.NextResultSet(); err != nil {
+		t.Fatalf("NextResultSet: %v", err)
+	}
+	dest := make([]driver.Value, 1)
+	if err := rc.Next(dest); err != nil {
+		t.Fatalf("Next: %v", err)
+	}
+	if dest[0] != "b" {
+		t.Errorf("Next() after NextResultSet returned %v, want the second result set's row %q", dest[0], "b")
+	}
+}
+
+func TestRowsColumnTypeNullableDefaultsToUnknown(t *testing.T) {
+	// A plain map[string]interface{} fixture never set Nullable, so there's
+	// no basis for reporting a column as definitely NOT NULL.
+	rc := &RowsCursor{colMeta: []Column{{Name: "x"}}}
+	if _, ok := rc.RowsColumnTypeNullable(0); ok {
+		t.Errorf("RowsColumnTypeNullable(0) ok = true for a map-based fixture, want false")
+	}
+
+	rc = &RowsCursor{colMeta: []Column{{Name: "x", Nullable: true}}, typedMeta: true}
+	nullable, ok := rc.RowsColumnTypeNullable(0)
+	if !ok || !nullable {
+		t.Errorf("RowsColumnTypeNullable(0) = (%v, %v) for an OrderedResponse fixture, want (true, true)", nullable, ok)
+	}
+}